@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// externalBuilderMetadata mirrors the subset of a chaincode package's
+// metadata.json that is relevant to identifying packages built for
+// external builders (i.e. chaincode-as-a-service packages).
+type externalBuilderMetadata struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// externalBuilderConnection mirrors the connection.json descriptor that an
+// external builder package carries inside its code.tar.gz, describing how
+// the peer should reach the already-running chaincode service.
+type externalBuilderConnection struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key"`
+	ClientCert         string `json:"client_cert"`
+	RootCert           string `json:"root_cert"`
+}
+
+// externalBuilderPackage is the validated, client-side view of an external
+// builder chaincode package.
+type externalBuilderPackage struct {
+	Metadata   externalBuilderMetadata
+	Connection externalBuilderConnection
+}
+
+const externalBuilderPackageType = "external"
+
+// detectExternalBuilderPackage inspects a chaincode install package and, if
+// it was produced for an external builder (metadata.json declares
+// "type": "external"), extracts and validates its connection.json
+// descriptor. It returns (nil, nil) when the package is not an external
+// builder package, so callers can fall through to their normal handling.
+func detectExternalBuilderPackage(pkgBytes []byte) (*externalBuilderPackage, error) {
+	metadataBytes, codeTarGz, err := readTopLevelPackageEntries(pkgBytes)
+	if err != nil {
+		// Not a well-formed chaincode package tarball at all; let the
+		// caller's existing format detection report the error.
+		return nil, nil
+	}
+
+	var metadata externalBuilderMetadata
+	if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+		return nil, nil
+	}
+
+	if metadata.Type != externalBuilderPackageType {
+		return nil, nil
+	}
+
+	// Which configured external builder (if any) ends up running this
+	// package is decided peer-side by each builder's detect script, not by
+	// a config field the client can match against metadata.Type. There is
+	// no sound client-side type-to-builder mapping to validate here.
+
+	connectionBytes, err := readFileFromTarGz(codeTarGz, "connection.json")
+	if err != nil {
+		return nil, errors.WithMessage(err, "error reading connection.json from external builder package")
+	}
+
+	var connection externalBuilderConnection
+	if err := json.Unmarshal(connectionBytes, &connection); err != nil {
+		return nil, errors.WithMessage(err, "error unmarshaling connection.json")
+	}
+
+	if connection.Address == "" {
+		return nil, errors.New("connection.json must specify a non-empty address")
+	}
+
+	return &externalBuilderPackage{Metadata: metadata, Connection: connection}, nil
+}
+
+// readTopLevelPackageEntries reads metadata.json and code.tar.gz out of the
+// outer package tarball without requiring any particular entry order.
+func readTopLevelPackageEntries(pkgBytes []byte) (metadataBytes []byte, codeTarGz []byte, err error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(pkgBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch header.Name {
+		case "metadata.json":
+			metadataBytes, err = ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, nil, err
+			}
+		case "code.tar.gz":
+			codeTarGz, err = ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if metadataBytes == nil {
+		return nil, nil, errors.New("package is missing metadata.json")
+	}
+	if codeTarGz == nil {
+		return nil, nil, errors.New("package is missing code.tar.gz")
+	}
+
+	return metadataBytes, codeTarGz, nil
+}
+
+// readFileFromTarGz extracts a single named file from a gzipped tar archive.
+func readFileFromTarGz(tarGzBytes []byte, name string) ([]byte, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(tarGzBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("%s not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Name == name {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeGetInstalledPackageCmd *cobra.Command
+
+const (
+	getinstalledpackageCmdName = "getinstalledpackage"
+)
+
+// GetInstalledPackageInput holds the input parameters for retrieving
+// a chaincode package previously installed on a peer
+type GetInstalledPackageInput struct {
+	PackageID  string
+	OutputFile string
+}
+
+// GetInstalledPackage holds the dependencies needed to retrieve
+// a chaincode package installed on a peer via _lifecycle
+type GetInstalledPackage struct {
+	Command         *cobra.Command
+	EndorserClients []pb.EndorserClient
+	Input           *GetInstalledPackageInput
+	Signer          msp.SigningIdentity
+	Writer          func(filename string, data []byte, perm os.FileMode) error
+}
+
+// getinstalledpackageCmd returns the cobra command for retrieving the
+// bytes of a chaincode package previously installed on a peer
+func getinstalledpackageCmd(cf *ChaincodeCmdFactory, g *GetInstalledPackage) *cobra.Command {
+	chaincodeGetInstalledPackageCmd = &cobra.Command{
+		Use:   "getinstalledpackage",
+		Short: "Get an installed chaincode package.",
+		Long:  "Get the installed chaincode package, by package ID, and write it to a file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if g == nil {
+				var err error
+				if cf == nil {
+					cf, err = InitCmdFactory(cmd.Name(), true, false)
+					if err != nil {
+						return err
+					}
+				}
+				g = &GetInstalledPackage{
+					Command:         cmd,
+					EndorserClients: cf.EndorserClients,
+					Signer:          cf.Signer,
+					Writer:          ioutil.WriteFile,
+				}
+			}
+			return g.getInstalledPackage(args)
+		},
+	}
+	flagList := []string{
+		"packageID",
+		"outputFile",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeGetInstalledPackageCmd, flagList)
+
+	return chaincodeGetInstalledPackageCmd
+}
+
+func (g *GetInstalledPackage) getInstalledPackage(args []string) error {
+	if g.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		g.Command.SilenceUsage = true
+	}
+
+	g.Input = &GetInstalledPackageInput{
+		PackageID:  packageID,
+		OutputFile: outputFile,
+	}
+
+	if g.Input.PackageID == "" {
+		return errors.New("The required parameter 'packageID' is empty. Rerun the command with -h option.")
+	}
+
+	if g.Input.OutputFile == "" {
+		return errors.New("The required parameter 'outputFile' is empty. Rerun the command with -h option.")
+	}
+
+	proposal, err := g.createProposal(g.Input.PackageID)
+	if err != nil {
+		return errors.WithMessage(err, "error creating proposal for getinstalledpackage")
+	}
+
+	signedProposal, err := protoutil.GetSignedProposal(proposal, g.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "error creating signed proposal for getinstalledpackage")
+	}
+
+	return g.submitGetInstalledPackage(signedProposal)
+}
+
+func (g *GetInstalledPackage) createProposal(packageID string) (*pb.Proposal, error) {
+	args := &lb.GetInstalledChaincodePackageArgs{
+		PackageId: packageID,
+	}
+
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling GetInstalledChaincodePackageArgs")
+	}
+	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte("GetInstalledChaincodePackage"), argsBytes}}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: newLifecycleName},
+			Input:       ccInput,
+		},
+	}
+
+	creatorBytes, err := g.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error serializing identity for %s", g.Signer.GetIdentifier()))
+	}
+
+	proposal, _, err := protoutil.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", cis, creatorBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating proposal for ChaincodeInvocationSpec")
+	}
+
+	return proposal, nil
+}
+
+func (g *GetInstalledPackage) submitGetInstalledPackage(signedProposal *pb.SignedProposal) error {
+	proposalResponse, err := g.EndorserClients[0].ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return errors.WithMessage(err, "error endorsing getinstalledpackage")
+	}
+
+	if proposalResponse == nil {
+		return errors.New("error during getinstalledpackage: received nil proposal response")
+	}
+
+	if proposalResponse.Response == nil {
+		return errors.New("error during getinstalledpackage: received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("getinstalledpackage failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	gicpr := &lb.GetInstalledChaincodePackageResult{}
+	if err := proto.Unmarshal(proposalResponse.Response.Payload, gicpr); err != nil {
+		return errors.Wrap(err, "error unmarshaling proposal response's response payload")
+	}
+
+	if err := g.Writer(g.Input.OutputFile, gicpr.ChaincodeInstallPackage, 0600); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error writing chaincode package to %s", g.Input.OutputFile))
+	}
+
+	logger.Infof("Chaincode package for %s written to %s", g.Input.PackageID, g.Input.OutputFile)
+
+	return nil
+}
@@ -8,8 +8,13 @@ package chaincode
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"sync"
+	"text/tabwriter"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
@@ -31,6 +36,12 @@ const (
 	installCmdName = "install"
 )
 
+var (
+	expectedPackageID string
+	dryRun            bool
+	collectSigsPolicy string
+)
+
 // Reader defines the interface needed for reading a file
 type Reader interface {
 	ReadFile(string) ([]byte, error)
@@ -55,6 +66,25 @@ type InstallInput struct {
 	PackageFile  string
 	Path         string
 	NewLifecycle bool
+
+	// ExternalBuilderConnection is populated when the package being
+	// installed was built for an external builder (chaincode-as-a-service);
+	// it is nil for packages built to be run by the peer itself.
+	ExternalBuilderConnection *externalBuilderConnection
+
+	// ExpectedPackageID, if set, is compared against the locally computed
+	// package hash before the install proposal is submitted, and against
+	// each peer's reported hash afterwards.
+	ExpectedPackageID string
+
+	// DryRun, when set, stops after computing and printing the local
+	// package hash without contacting any peer.
+	DryRun bool
+
+	// localPackageID is the hash computed from the package bytes on disk,
+	// used to detect drift between what was requested and what the peers
+	// report installing.
+	localPackageID string
 }
 
 // installCmd returns the cobra command for chaincode install
@@ -62,7 +92,7 @@ func installCmd(cf *ChaincodeCmdFactory, i *Installer) *cobra.Command {
 	chaincodeInstallCmd = &cobra.Command{
 		Use:       "install",
 		Short:     "Install a chaincode.",
-		Long:      "Install a chaincode on a peer. For the legacy lifecycle (lscc), this installs a chaincode deployment spec package (if provided) or packages the specified chaincode before subsequently installing it.",
+		Long:      "Install a chaincode on one or more peers. When multiple --peerAddresses are given, the install proposal is submitted to every peer concurrently and a summary of each peer's outcome is printed; the command exits non-zero if any peer failed. For the legacy lifecycle (lscc), this installs a chaincode deployment spec package (if provided) or packages the specified chaincode before subsequently installing it.",
 		ValidArgs: []string{"1"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if i == nil {
@@ -96,6 +126,10 @@ func installCmd(cf *ChaincodeCmdFactory, i *Installer) *cobra.Command {
 	}
 	attachFlags(chaincodeInstallCmd, flagList)
 
+	chaincodeInstallCmd.Flags().StringVar(&expectedPackageID, "expected-package-id", "", "with --newLifecycle, the package ID the installed package's hash is expected to match; install fails fast if it does not (not checked for legacy lscc installs)")
+	chaincodeInstallCmd.Flags().BoolVar(&dryRun, "dry-run", false, "with --newLifecycle, compute and print the package ID without contacting a peer (legacy lscc installs ignore this flag and are submitted)")
+	chaincodeInstallCmd.Flags().StringVar(&collectSigsPolicy, "collect-sigs-policy", "", "the endorsement policy the accumulated signatures on a co-signed --newLifecycle package are expected to satisfy; required to install such a package, but only logged: the signatures are not checked against it")
+
 	return chaincodeInstallCmd
 }
 
@@ -119,10 +153,12 @@ func (i *Installer) installChaincode(args []string) error {
 
 func (i *Installer) setInput(args []string) {
 	i.Input = &InstallInput{
-		Name:         chaincodeName,
-		Version:      chaincodeVersion,
-		Path:         chaincodePath,
-		NewLifecycle: newLifecycle,
+		Name:              chaincodeName,
+		Version:           chaincodeVersion,
+		Path:              chaincodePath,
+		NewLifecycle:      newLifecycle,
+		ExpectedPackageID: expectedPackageID,
+		DryRun:            dryRun,
 	}
 
 	if len(args) > 0 {
@@ -142,6 +178,40 @@ func (i *Installer) install() error {
 		return errors.WithMessage(err, fmt.Sprintf("error reading chaincode package at %s", i.Input.PackageFile))
 	}
 
+	signedEnv, err := unmarshalLifecyclePackageEnvelope(pkgBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error reading signed chaincode package")
+	}
+	if signedEnv != nil {
+		if collectSigsPolicy == "" {
+			return errors.New("installing a signed package requires --collect-sigs-policy")
+		}
+		pkgBytes, err = stripLifecyclePackageEnvelope(signedEnv)
+		if err != nil {
+			return errors.WithMessage(err, "error reading signed chaincode package")
+		}
+		logger.Infof("Installing package co-signed with %d signature(s); the signatures are not checked against policy %q", len(signedEnv.Signatures), collectSigsPolicy)
+	}
+
+	externalPkg, err := detectExternalBuilderPackage(pkgBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error validating external builder package")
+	}
+	if externalPkg != nil {
+		i.Input.ExternalBuilderConnection = &externalPkg.Connection
+		logger.Infof("Installing external builder package for chaincode-as-a-service at %s", externalPkg.Connection.Address)
+	}
+
+	i.Input.localPackageID = computePackageID(pkgBytes)
+	if i.Input.ExpectedPackageID != "" && i.Input.ExpectedPackageID != i.Input.localPackageID {
+		return errors.Errorf("expected package ID %s does not match locally computed package ID %s", i.Input.ExpectedPackageID, i.Input.localPackageID)
+	}
+
+	if i.Input.DryRun {
+		fmt.Fprintf(os.Stdout, "%s\n", i.Input.localPackageID)
+		return nil
+	}
+
 	serializedSigner, err := i.Signer.Serialize()
 	if err != nil {
 		errors.WithMessage(err, fmt.Sprintf("error serializing signer for %v", i.Signer.GetIdentifier()))
@@ -181,33 +251,108 @@ func (i *Installer) installLegacy() error {
 	return i.submitInstallProposal(signedProposal)
 }
 
+// computePackageID returns the hex-encoded SHA-256 hash of a chaincode
+// install package, matching the hash _lifecycle computes server-side over
+// the same ChaincodeInstallPackage bytes.
+func computePackageID(pkgBytes []byte) string {
+	hash := sha256.Sum256(pkgBytes)
+	return hex.EncodeToString(hash[:])
+}
+
+// installPeerResult captures the outcome of submitting an install proposal
+// to a single endorser.
+type installPeerResult struct {
+	peerAddress string
+	packageID   string
+	err         error
+}
+
+// peerAddressAt returns the address associated with the endorser at the
+// given index, falling back to a positional label when the peer addresses
+// flag was not populated (e.g. unit tests constructing an Installer by hand).
+func peerAddressAt(idx int) string {
+	if idx < len(peerAddresses) && peerAddresses[idx] != "" {
+		return peerAddresses[idx]
+	}
+	return fmt.Sprintf("peer%d", idx)
+}
+
 func (i *Installer) submitInstallProposal(signedProposal *pb.SignedProposal) error {
-	// install is currently only supported for one peer
-	proposalResponse, err := i.EndorserClients[0].ProcessProposal(context.Background(), signedProposal)
+	results := make([]installPeerResult, len(i.EndorserClients))
+
+	var wg sync.WaitGroup
+	wg.Add(len(i.EndorserClients))
+	for idx, endorserClient := range i.EndorserClients {
+		idx, endorserClient := idx, endorserClient
+		go func() {
+			defer wg.Done()
+			results[idx] = i.submitInstallProposalToPeer(idx, endorserClient, signedProposal)
+		}()
+	}
+	wg.Wait()
+
+	return i.reportInstallResults(results)
+}
+
+func (i *Installer) submitInstallProposalToPeer(idx int, endorserClient pb.EndorserClient, signedProposal *pb.SignedProposal) installPeerResult {
+	peerAddress := peerAddressAt(idx)
+
+	proposalResponse, err := endorserClient.ProcessProposal(context.Background(), signedProposal)
 	if err != nil {
-		return errors.WithMessage(err, "error endorsing chaincode install")
+		return installPeerResult{peerAddress: peerAddress, err: errors.WithMessage(err, "error endorsing chaincode install")}
 	}
 
 	if proposalResponse == nil {
-		return errors.New("error during install: received nil proposal response")
+		return installPeerResult{peerAddress: peerAddress, err: errors.New("error during install: received nil proposal response")}
 	}
 
 	if proposalResponse.Response == nil {
-		return errors.New("error during install: received proposal response with nil response")
+		return installPeerResult{peerAddress: peerAddress, err: errors.New("error during install: received proposal response with nil response")}
 	}
 
 	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
-		return errors.Errorf("install failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+		return installPeerResult{peerAddress: peerAddress, err: errors.Errorf("install failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)}
 	}
-	logger.Infof("Installed remotely: %v", proposalResponse)
+	logger.Infof("Installed on %s: %v", peerAddress, proposalResponse)
 
 	if i.Input.NewLifecycle {
 		icr := &lb.InstallChaincodeResult{}
-		err := proto.Unmarshal(proposalResponse.Response.Payload, icr)
-		if err != nil {
-			return errors.Wrap(err, "error unmarshaling proposal response's response payload")
+		if err := proto.Unmarshal(proposalResponse.Response.Payload, icr); err != nil {
+			return installPeerResult{peerAddress: peerAddress, err: errors.Wrap(err, "error unmarshaling proposal response's response payload")}
 		}
-		logger.Infof("Chaincode code package hash: %x", icr.Hash)
+		logger.Infof("Chaincode code package hash on %s: %x", peerAddress, icr.Hash)
+		packageID := hex.EncodeToString(icr.Hash)
+		if i.Input.localPackageID != "" && packageID != i.Input.localPackageID {
+			return installPeerResult{peerAddress: peerAddress, err: errors.Errorf("package hash %s returned by peer does not match locally computed hash %s", packageID, i.Input.localPackageID)}
+		}
+		return installPeerResult{peerAddress: peerAddress, packageID: packageID}
+	}
+
+	return installPeerResult{peerAddress: peerAddress}
+}
+
+// reportInstallResults prints a summary table of the per-peer install
+// outcomes and returns an aggregate error if any peer failed.
+// submitInstallProposalToPeer already rejects any peer whose reported hash
+// disagrees with the locally computed package hash, so cross-peer
+// consistency is enforced there rather than recomputed here.
+func (i *Installer) reportInstallResults(results []installPeerResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PEER\tSTATUS\tPACKAGE ID")
+
+	var failures int
+	for _, result := range results {
+		if result.err != nil {
+			failures++
+			fmt.Fprintf(w, "%s\tFAILED: %s\t\n", result.peerAddress, result.err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\tOK\t%s\n", result.peerAddress, result.packageID)
+	}
+	w.Flush()
+
+	if failures > 0 {
+		return errors.Errorf("install failed on %d of %d peer(s)", failures, len(results))
 	}
 
 	return nil
@@ -218,6 +363,13 @@ func (i *Installer) validateInput() error {
 		return errors.New("chaincode install package must be provided")
 	}
 
+	// --dry-run only computes and prints the local package hash, so it
+	// does not require the name/version/path that are only meaningful
+	// once an install proposal is actually submitted.
+	if i.Input.DryRun {
+		return nil
+	}
+
 	if i.Input.Name == "" {
 		return errors.New("chaincode name must be specified")
 	}
@@ -341,6 +493,12 @@ func getPackageFromFile(ccPkgFile string) (proto.Message, *pb.ChaincodeDeploymen
 		return nil, nil, err
 	}
 
+	if externalPkg, err := detectExternalBuilderPackage(ccPkgBytes); err != nil {
+		return nil, nil, errors.WithMessage(err, "error validating external builder package")
+	} else if externalPkg != nil {
+		return nil, nil, errors.New("external builder packages are only supported with --newLifecycle")
+	}
+
 	// the bytes should be a valid package (CDS or SignedCDS)
 	ccpack, err := ccprovider.GetCCPackage(ccPkgBytes)
 	if err != nil {
@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeQueryInstalledCmd *cobra.Command
+
+const (
+	queryInstalledCmdName = "queryinstalled"
+)
+
+// QueryInstalledInput holds the input parameters for querying
+// the chaincodes installed on a peer
+type QueryInstalledInput struct {
+	OutputFormat string
+}
+
+// QueryInstalledChaincodes holds the dependencies needed to query
+// the chaincodes installed on a peer via _lifecycle
+type QueryInstalledChaincodes struct {
+	Command         *cobra.Command
+	EndorserClients []pb.EndorserClient
+	Input           *QueryInstalledInput
+	Signer          msp.SigningIdentity
+}
+
+// queryInstalledCmd returns the cobra command for listing
+// the chaincodes installed on a peer
+func queryInstalledCmd(cf *ChaincodeCmdFactory, q *QueryInstalledChaincodes) *cobra.Command {
+	chaincodeQueryInstalledCmd = &cobra.Command{
+		Use:   "queryinstalled",
+		Short: "Query the chaincodes installed on a peer.",
+		Long:  "Query the chaincodes installed on a peer via _lifecycle.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if q == nil {
+				var err error
+				if cf == nil {
+					cf, err = InitCmdFactory(cmd.Name(), true, false)
+					if err != nil {
+						return err
+					}
+				}
+				q = &QueryInstalledChaincodes{
+					Command:         cmd,
+					EndorserClients: cf.EndorserClients,
+					Signer:          cf.Signer,
+				}
+			}
+			return q.queryInstalled()
+		},
+	}
+	flagList := []string{
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"output",
+	}
+	attachFlags(chaincodeQueryInstalledCmd, flagList)
+
+	return chaincodeQueryInstalledCmd
+}
+
+// queryInstalled issues a QueryInstalledChaincodes proposal and prints the result
+func (q *QueryInstalledChaincodes) queryInstalled() error {
+	if q.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		q.Command.SilenceUsage = true
+	}
+
+	q.Input = &QueryInstalledInput{OutputFormat: output}
+
+	proposal, err := q.createProposal()
+	if err != nil {
+		return errors.WithMessage(err, "error creating proposal for queryinstalled")
+	}
+
+	signedProposal, err := protoutil.GetSignedProposal(proposal, q.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "error creating signed proposal for queryinstalled")
+	}
+
+	return q.submitQueryInstalled(signedProposal)
+}
+
+func (q *QueryInstalledChaincodes) createProposal() (*pb.Proposal, error) {
+	args := &lb.QueryInstalledChaincodesArgs{}
+
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling QueryInstalledChaincodesArgs")
+	}
+	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte("QueryInstalledChaincodes"), argsBytes}}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: newLifecycleName},
+			Input:       ccInput,
+		},
+	}
+
+	creatorBytes, err := q.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error serializing identity for %s", q.Signer.GetIdentifier()))
+	}
+
+	proposal, _, err := protoutil.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", cis, creatorBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating proposal for ChaincodeInvocationSpec")
+	}
+
+	return proposal, nil
+}
+
+func (q *QueryInstalledChaincodes) submitQueryInstalled(signedProposal *pb.SignedProposal) error {
+	proposalResponse, err := q.EndorserClients[0].ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return errors.WithMessage(err, "error endorsing queryinstalled")
+	}
+
+	if proposalResponse == nil {
+		return errors.New("error during queryinstalled: received nil proposal response")
+	}
+
+	if proposalResponse.Response == nil {
+		return errors.New("error during queryinstalled: received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("queryinstalled failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	qicr := &lb.QueryInstalledChaincodesResult{}
+	if err := proto.Unmarshal(proposalResponse.Response.Payload, qicr); err != nil {
+		return errors.Wrap(err, "error unmarshaling proposal response's response payload")
+	}
+
+	return printInstalledChaincodes(qicr, q.Input.OutputFormat)
+}
+
+func printInstalledChaincodes(qicr *lb.QueryInstalledChaincodesResult, outputFormat string) error {
+	if outputFormat == "json" {
+		m := jsonpb.Marshaler{Indent: "  "}
+		return m.Marshal(os.Stdout, qicr)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PACKAGE ID\tLABEL")
+	for _, ic := range qicr.InstalledChaincodes {
+		fmt.Fprintf(w, "%s\t%s\n", ic.PackageId, ic.Label)
+	}
+	return w.Flush()
+}
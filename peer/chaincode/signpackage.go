@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeSignPackageCmd *cobra.Command
+
+// signpackageCmd returns the cobra command for signing a chaincode package
+func signpackageCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeSignPackageCmd = &cobra.Command{
+		Use:       "signpackage",
+		Short:     "Sign the specified chaincode package.",
+		Long:      "Sign the specified chaincode package to support multi-org co-signing before installation. With --newLifecycle, the package is wrapped (or re-wrapped) in an envelope that accumulates one signature per invocation, so owners can sign in turn without coordinating a single signing step.",
+		ValidArgs: []string{"2"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return signpackage(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"cafile",
+		"newLifecycle",
+	}
+	attachFlags(chaincodeSignPackageCmd, flagList)
+
+	return chaincodeSignPackageCmd
+}
+
+func signpackage(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if len(args) != 2 {
+		return errors.New("peer chaincode signpackage <inputpackage> <outputpackage>")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), false, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	inputBytes, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error reading package at %s", args[0]))
+	}
+
+	var outputBytes []byte
+	if newLifecycle {
+		outputBytes, err = signNewLifecyclePackage(inputBytes, cf.Signer)
+		if err != nil {
+			return errors.WithMessage(err, "error signing _lifecycle chaincode package")
+		}
+	} else {
+		env, err := ccpackage.SignExistingPackage(inputBytes, cf.Signer)
+		if err != nil {
+			return errors.WithMessage(err, "error signing chaincode package")
+		}
+		outputBytes, err = proto.Marshal(env)
+		if err != nil {
+			return errors.Wrap(err, "error marshaling signed package")
+		}
+	}
+
+	err = ioutil.WriteFile(args[1], outputBytes, 0700)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error writing signed package to %s", args[1]))
+	}
+
+	logger.Infof("Signed package written to %s", args[1])
+
+	return nil
+}